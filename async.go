@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DropMode controls what happens when an async logger's buffer is full.
+type DropMode int
+
+const (
+	// Block waits for room in the buffer, applying backpressure to the
+	// caller. This is the safest mode but can stall hot paths.
+	Block DropMode = iota
+
+	// DropOldest discards the oldest buffered entry to make room for the
+	// new one, favoring recent log lines over completeness.
+	DropOldest
+
+	// DropNewest discards the incoming entry when the buffer is full,
+	// preserving whatever was already queued.
+	DropNewest
+)
+
+// asyncMsg is sent over a Logger's async channel. A non-nil flush channel
+// marks a Flush() barrier rather than a log line to write.
+type asyncMsg struct {
+	data  []byte
+	flush chan struct{}
+}
+
+// SetAsync switches the logger to asynchronous writes: formatted entries are
+// handed to a background goroutine over a channel of size bufSize instead of
+// being written to output synchronously. mode controls what happens once the
+// buffer fills. Calling SetAsync on an already-async logger is a no-op.
+func (l *Logger) SetAsync(bufSize int, mode DropMode) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.asyncCh != nil {
+		return l
+	}
+
+	ch := make(chan asyncMsg, bufSize)
+	done := make(chan struct{})
+	l.asyncCh = ch
+	l.asyncMode = mode
+	l.asyncDone = done
+	l.asyncWG = &sync.WaitGroup{}
+
+	go l.asyncWriter(ch, done)
+	return l
+}
+
+func (l *Logger) asyncWriter(ch chan asyncMsg, done chan struct{}) {
+	defer close(done)
+	for msg := range ch {
+		if msg.flush != nil {
+			close(msg.flush)
+			continue
+		}
+		l.writeSync(msg.data)
+	}
+}
+
+func (l *Logger) writeSync(data []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(l.output, string(data))
+}
+
+// acquireAsyncChan returns the logger's async channel and mode, registering
+// the caller as an in-flight sender via asyncWG so Close can safely wait for
+// it before closing the channel. A nil channel means async writing is
+// disabled (or being torn down); callers must not send on it and must not
+// call asyncWG.Done.
+func (l *Logger) acquireAsyncChan() (chan asyncMsg, DropMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ch := l.asyncCh
+	if ch != nil {
+		l.asyncWG.Add(1)
+	}
+	return ch, l.asyncMode
+}
+
+// write delivers data to output, going through the async buffer and its
+// configured DropMode when async writing is enabled.
+func (l *Logger) write(data []byte) {
+	ch, mode := l.acquireAsyncChan()
+	if ch == nil {
+		l.writeSync(data)
+		return
+	}
+	defer l.asyncWG.Done()
+
+	msg := asyncMsg{data: data}
+	switch mode {
+	case DropNewest:
+		select {
+		case ch <- msg:
+		default:
+			// Buffer full: drop this entry rather than block the caller.
+		}
+	case DropOldest:
+		for {
+			select {
+			case ch <- msg:
+				return
+			default:
+			}
+			select {
+			case old := <-ch:
+				if old.flush != nil {
+					// Never drop a Flush barrier; requeue it behind newer entries.
+					ch <- old
+				}
+			default:
+			}
+		}
+	default: // Block
+		ch <- msg
+	}
+}
+
+// Flush blocks until every entry queued before this call has been written.
+// It is a no-op on a synchronous logger.
+func (l *Logger) Flush() {
+	ch, _ := l.acquireAsyncChan()
+	if ch == nil {
+		return
+	}
+	defer l.asyncWG.Done()
+
+	done := make(chan struct{})
+	ch <- asyncMsg{flush: done}
+	<-done
+}
+
+// Close flushes any buffered entries and stops the background writer
+// goroutine, returning the logger to synchronous writes. It waits for every
+// sender that already grabbed the channel before closing it, so write/Flush
+// calls racing with Close never panic with "send on closed channel".
+func (l *Logger) Close() {
+	l.Flush()
+
+	l.mu.Lock()
+	ch := l.asyncCh
+	done := l.asyncDone
+	l.asyncCh = nil
+	l.asyncDone = nil
+	l.mu.Unlock()
+
+	if ch == nil {
+		return
+	}
+	l.asyncWG.Wait()
+	close(ch)
+	<-done
+}
+
+func SetAsync(bufSize int, mode DropMode) *Logger { return defaultLogger.SetAsync(bufSize, mode) }
+func Flush()                                      { defaultLogger.Flush() }
+func Close()                                      { defaultLogger.Close() }
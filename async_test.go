@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+// TestSetAsyncClose reproduces Close running concurrently with in-flight
+// Info calls. Close must wait for every sender that already grabbed the
+// async channel before closing it, or this panics with "send on closed
+// channel".
+func TestSetAsyncClose(t *testing.T) {
+	for iter := 0; iter < 50; iter++ {
+		l := New(io.Discard)
+		l.SetAsync(4, Block)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Info("hello")
+			}()
+		}
+
+		l.Close()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextFieldExtractor extracts fields (e.g. a trace or request ID) from a
+// context.Context so they can be injected into every entry logged through
+// WithContext.
+type ContextFieldExtractor func(ctx context.Context) Fields
+
+// contextExtractors is shared by every logger instance; extractors are
+// typically registered once at program startup.
+var (
+	contextExtractors   []ContextFieldExtractor
+	contextExtractorsMu sync.Mutex
+)
+
+// RegisterContextFieldExtractor adds an extractor that runs whenever
+// WithContext (or a *Ctx method) is called, merging its returned fields into
+// the resulting logger.
+func RegisterContextFieldExtractor(extractor ContextFieldExtractor) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// WithContext returns a logger with fields extracted from ctx by every
+// registered ContextFieldExtractor merged in. The context itself is not
+// retained; only the fields it yields at call time are.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	contextExtractorsMu.Lock()
+	extractors := make([]ContextFieldExtractor, len(contextExtractors))
+	copy(extractors, contextExtractors)
+	contextExtractorsMu.Unlock()
+
+	if len(extractors) == 0 {
+		return l
+	}
+
+	fields := make(Fields)
+	for _, extractor := range extractors {
+		for k, v := range extractor(ctx) {
+			fields[k] = v
+		}
+	}
+	return l.AddFields(fields)
+}
+
+// DebugCtx logs a debug message with fields extracted from ctx.
+func (l *Logger) DebugCtx(ctx context.Context, msg string) { l.WithContext(ctx).Debug(msg) }
+
+// InfoCtx logs an info message with fields extracted from ctx.
+func (l *Logger) InfoCtx(ctx context.Context, msg string) { l.WithContext(ctx).Info(msg) }
+
+// WarnCtx logs a warning message with fields extracted from ctx.
+func (l *Logger) WarnCtx(ctx context.Context, msg string) { l.WithContext(ctx).Warn(msg) }
+
+// ErrorCtx logs an error message with fields extracted from ctx.
+func (l *Logger) ErrorCtx(ctx context.Context, msg string) { l.WithContext(ctx).Error(msg) }
+
+func WithContext(ctx context.Context) *Logger  { return defaultLogger.WithContext(ctx) }
+func DebugCtx(ctx context.Context, msg string) { defaultLogger.DebugCtx(ctx, msg) }
+func InfoCtx(ctx context.Context, msg string)  { defaultLogger.InfoCtx(ctx, msg) }
+func WarnCtx(ctx context.Context, msg string)  { defaultLogger.WarnCtx(ctx, msg) }
+func ErrorCtx(ctx context.Context, msg string) { defaultLogger.ErrorCtx(ctx, msg) }
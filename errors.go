@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultErrorKey = "error"
+
+// packagePath is this package's import path, resolved at runtime so caller
+// detection below doesn't need to hardcode it.
+var packagePath = reflect.TypeOf(Logger{}).PkgPath()
+
+// withCallerFields returns a copy of base plus "file", "line", and "func"
+// entries describing the first call frame outside this package, without
+// mutating base itself.
+func (l *Logger) withCallerFields(base Fields) Fields {
+	fields := make(Fields, len(base)+3)
+	for k, v := range base {
+		fields[k] = v
+	}
+
+	file, line, fn := callerInfo()
+	fields["file"] = file
+	fields["line"] = line
+	fields["func"] = fn
+	return fields
+}
+
+// callerInfo walks the call stack past every frame belonging to this
+// package and returns the first external frame's file, line, and function.
+func callerInfo() (file string, line int, function string) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			return frame.File, frame.Line, frame.Function
+		}
+		if !more {
+			return "", 0, ""
+		}
+	}
+}
+
+// SetErrorKey overrides the field name WithError stores the error under.
+// Defaults to "error".
+func (l *Logger) SetErrorKey(key string) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errorKey = key
+	return l
+}
+
+// WithError returns a logger with err recorded under the configured error
+// key (see SetErrorKey). If err exposes a pkg/errors-style
+// `StackTrace() errors.StackTrace` method, that trace is attached under
+// "stacktrace"; otherwise a stack is captured at the call site via
+// runtime.Callers. A nil err is recorded as-is, with no stacktrace.
+func (l *Logger) WithError(err error) *Logger {
+	key := l.errorKey
+	if key == "" {
+		key = defaultErrorKey
+	}
+
+	if err == nil {
+		return l.AddFields(Fields{key: nil})
+	}
+
+	fields := Fields{key: err.Error()}
+	if trace, ok := errStackTrace(err); ok {
+		fields["stacktrace"] = trace
+	} else {
+		fields["stacktrace"] = captureStack()
+	}
+
+	return l.AddFields(fields)
+}
+
+// errStackTrace duck-types err against pkg/errors' withStack interface
+// (`StackTrace() errors.StackTrace`) via reflection, so this package doesn't
+// need to depend on pkg/errors to support it.
+func errStackTrace(err error) (string, bool) {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return "", false
+	}
+	out := method.Call(nil)
+	return fmt.Sprintf("%+v", out[0].Interface()), true
+}
+
+// captureStack renders the call stack starting at the first frame outside
+// this package, regardless of how many of this package's own methods sit
+// between the real call site and here.
+func captureStack() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+
+	buf := &bytes.Buffer{}
+	frames := runtime.CallersFrames(pcs[:n])
+	inPackage := true
+	for {
+		frame, more := frames.Next()
+		if inPackage {
+			if strings.HasPrefix(frame.Function, packagePath+".") {
+				if !more {
+					break
+				}
+				continue
+			}
+			inPackage = false
+		}
+		fmt.Fprintf(buf, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return buf.String()
+}
+
+func WithError(err error) *Logger    { return defaultLogger.WithError(err) }
+func SetErrorKey(key string) *Logger { return defaultLogger.SetErrorKey(key) }
+
+// SetReportCaller controls whether every entry records the file, line, and
+// function of its call site. It's stored as an atomic flag rather than
+// under l.mu because log() reads it on every call without taking the lock.
+func (l *Logger) SetReportCaller(v bool) *Logger {
+	val := int32(0)
+	if v {
+		val = 1
+	}
+	atomic.StoreInt32(&l.reportCaller, val)
+	return l
+}
+
+// reportCallerEnabled reports whether SetReportCaller(true) is in effect.
+func (l *Logger) reportCallerEnabled() bool {
+	return atomic.LoadInt32(&l.reportCaller) != 0
+}
+
+// SetReportCaller sets ReportCaller on the default logger.
+func SetReportCaller(v bool) *Logger { return defaultLogger.SetReportCaller(v) }
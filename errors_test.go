@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+// TestSetReportCallerRace reproduces SetReportCaller running concurrently
+// with Info calls that read it. log() reads the flag without l.mu, so it
+// must be synchronized some other way or this is a data race.
+func TestSetReportCallerRace(t *testing.T) {
+	l := New(io.Discard)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				l.SetReportCaller(i%4 == 0)
+			} else {
+				l.Info("hello")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
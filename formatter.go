@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+)
+
+// Entry represents a single log record passed to a Formatter.
+type Entry struct {
+	Level   LogLevel
+	Message string
+	Time    string
+	Fields  Fields
+}
+
+// Formatter turns an Entry into the bytes that get written to the logger's
+// output. Implementations must not mutate entry.Fields.
+type Formatter interface {
+	Format(entry *Entry) ([]byte, error)
+}
+
+// JSONFormatter renders entries as a single JSON object per line. It is the
+// default formatter and preserves the historical field names.
+type JSONFormatter struct {
+	// TimestampKey overrides the JSON key used for the entry's timestamp.
+	// Defaults to "time".
+	TimestampKey string
+
+	// PrettyPrint indents the JSON output with two spaces instead of
+	// emitting it on a single line.
+	PrettyPrint bool
+}
+
+func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampKey := f.TimestampKey
+	if timestampKey == "" {
+		timestampKey = "time"
+	}
+
+	data := make(Fields, len(entry.Fields)+3)
+	for k, v := range entry.Fields {
+		data[k] = v
+	}
+	data["level"] = entry.Level.String()
+	data["message"] = entry.Message
+	data[timestampKey] = entry.Time
+
+	if f.PrettyPrint {
+		return json.MarshalIndent(data, "", "  ")
+	}
+	return json.Marshal(data)
+}
+
+// TextFormatter renders entries as key=value pairs with the level, time and
+// message first, followed by the remaining fields in sorted order.
+type TextFormatter struct {
+	// DisableColors turns off the colors a terminal destination would
+	// otherwise get. It does not override ForceColors.
+	DisableColors bool
+
+	// ForceColors forces colorized output even when the destination is not
+	// a terminal.
+	ForceColors bool
+
+	// EnvironmentOverrideColors allows the NO_COLOR and CLICOLOR_FORCE
+	// environment variables to override the Force/DisableColors settings.
+	EnvironmentOverrideColors bool
+
+	// isTerminal is resolved lazily by SetOutput/SetFormatter based on the logger's
+	// output destination. It's accessed via setIsTerminal/isTerminalEnabled
+	// because a single TextFormatter can be shared across cloned loggers
+	// (e.g. via AddField), each mutating it from its own goroutine.
+	isTerminal int32
+}
+
+func (f *TextFormatter) setIsTerminal(v bool) {
+	val := int32(0)
+	if v {
+		val = 1
+	}
+	atomic.StoreInt32(&f.isTerminal, val)
+}
+
+func (f *TextFormatter) isTerminalEnabled() bool {
+	return atomic.LoadInt32(&f.isTerminal) != 0
+}
+
+var levelColors = map[LogLevel]int{
+	LevelDebug: 34, // blue
+	LevelInfo:  32, // green
+	LevelWarn:  33, // yellow
+	LevelError: 31, // red
+	LevelFatal: 35, // magenta
+	LevelPanic: 35, // magenta
+}
+
+// isTerminalWriter reports whether w is a character device such as an
+// interactive terminal, as opposed to a regular file or pipe. On Windows it
+// also attempts to enable ANSI escape sequence processing on the console,
+// falling back to uncolored output if that fails.
+func isTerminalWriter(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	if info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	return enableVirtualTerminalProcessing(file)
+}
+
+func (f *TextFormatter) useColors() bool {
+	enabled := f.ForceColors || (f.isTerminalEnabled() && !f.DisableColors)
+	if f.EnvironmentOverrideColors {
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			enabled = false
+		}
+		if _, ok := os.LookupEnv("CLICOLOR_FORCE"); ok {
+			enabled = true
+		}
+	}
+	return enabled
+}
+
+func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	level := entry.Level.String()
+	if f.useColors() {
+		color := levelColors[entry.Level]
+		fmt.Fprintf(buf, "\x1b[%dm%-5s\x1b[0m", color, level)
+	} else {
+		fmt.Fprintf(buf, "%-5s", level)
+	}
+
+	fmt.Fprintf(buf, " time=%q msg=%q", entry.Time, entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(buf, " %s=%v", k, entry.Fields[k])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// LogfmtFormatter renders entries using the logfmt convention
+// (key=value pairs, space separated, no colors).
+type LogfmtFormatter struct{}
+
+func (f *LogfmtFormatter) Format(entry *Entry) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "time=%q level=%s msg=%q", entry.Time, entry.Level.String(), entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(buf, " %s=%v", k, entry.Fields[k])
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logger
+
+import "os"
+
+// enableVirtualTerminalProcessing is a no-op outside Windows, where ANSI
+// escape sequences are interpreted natively by terminal emulators.
+func enableVirtualTerminalProcessing(file *os.File) bool {
+	return true
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package logger
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// enableVirtualTerminalProcessingFlag is ENABLE_VIRTUAL_TERMINAL_PROCESSING.
+const enableVirtualTerminalProcessingFlag = 0x0004
+
+// enableVirtualTerminalProcessing turns on ANSI escape sequence
+// interpretation for a Windows console handle, which is off by default on
+// older Windows terminals. It reports whether the mode was (or already was)
+// successfully enabled. It calls kernel32.dll directly via syscall.NewLazyDLL
+// so this package stays stdlib-only instead of depending on
+// golang.org/x/sys/windows.
+func enableVirtualTerminalProcessing(file *os.File) bool {
+	handle := syscall.Handle(file.Fd())
+
+	var mode uint32
+	ok, _, _ := procGetConsoleMode.Call(uintptr(handle), uintptr(unsafe.Pointer(&mode)))
+	if ok == 0 {
+		return false
+	}
+
+	if mode&enableVirtualTerminalProcessingFlag != 0 {
+		return true
+	}
+
+	ok, _, _ = procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessingFlag))
+	return ok != 0
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+)
+
+// Hook allows log entries to be fanned out to external sinks (e.g. Sentry,
+// syslog, a Kafka topic, or an HTTP webhook) in addition to the logger's
+// primary output.
+type Hook interface {
+	// Levels returns the set of levels this hook wants to receive. An
+	// entry is fired to the hook only if its level is included.
+	Levels() []LogLevel
+
+	// Fire is called with the entry once it has cleared level filtering.
+	Fire(entry *Entry) error
+}
+
+// LevelHooks maps a log level to the hooks registered for it.
+type LevelHooks map[LogLevel][]Hook
+
+// add registers hook for every level it declares interest in.
+func (h LevelHooks) add(hook Hook) {
+	for _, level := range hook.Levels() {
+		h[level] = append(h[level], hook)
+	}
+}
+
+// clone returns a deep copy of h, so a logger derived via AddField/AddFields
+// can register its own hooks without racing with the logger it was derived
+// from (each *Logger only ever touches its own hooks map under its own
+// mutex).
+func (h LevelHooks) clone() LevelHooks {
+	copied := make(LevelHooks, len(h))
+	for level, hooks := range h {
+		copied[level] = append([]Hook(nil), hooks...)
+	}
+	return copied
+}
+
+// fire runs every hook registered for level against entry, reporting any
+// error to handler.
+func (h LevelHooks) fire(level LogLevel, entry *Entry, handler HookErrorHandler) {
+	for _, hook := range h[level] {
+		if err := hook.Fire(entry); err != nil {
+			handler(hook, entry, err)
+		}
+	}
+}
+
+// HookErrorHandler is called when a hook's Fire method returns an error. The
+// default handler writes a message to stderr.
+type HookErrorHandler func(hook Hook, entry *Entry, err error)
+
+func defaultHookErrorHandler(hook Hook, entry *Entry, err error) {
+	fmt.Fprintf(os.Stderr, "logger: hook %T failed to fire for %q: %v\n", hook, entry.Message, err)
+}
+
+// AddHook registers a hook to receive entries for the levels it declares.
+func (l *Logger) AddHook(hook Hook) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks.add(hook)
+	return l
+}
+
+// SetHookErrorHandler overrides how errors returned by hooks are reported.
+// Passing nil restores the default handler, which prints to stderr.
+func (l *Logger) SetHookErrorHandler(handler HookErrorHandler) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if handler == nil {
+		handler = defaultHookErrorHandler
+	}
+	l.hookErrorHandler = handler
+	return l
+}
+
+func AddHook(hook Hook) *Logger { return defaultLogger.AddHook(hook) }
+func SetHookErrorHandler(handler HookErrorHandler) *Logger {
+	return defaultLogger.SetHookErrorHandler(handler)
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+type countingHook struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (h *countingHook) Levels() []LogLevel { return []LogLevel{LevelInfo} }
+
+func (h *countingHook) Fire(entry *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.count++
+	return nil
+}
+
+// TestClonedLoggerAddHook reproduces loggers derived from the same base via
+// AddField concurrently registering hooks — an ordinary "per-request logger"
+// pattern. Each derived logger must own its hooks independently rather than
+// sharing the base's map by reference, or this is a concurrent map write.
+func TestClonedLoggerAddHook(t *testing.T) {
+	base := New(io.Discard)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			child := base.AddField("n", i)
+			child.AddHook(&countingHook{})
+		}(i)
+	}
+	wg.Wait()
+}
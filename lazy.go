@@ -0,0 +1,83 @@
+package logger
+
+import "os"
+
+// LogFunction builds a log message lazily. It is only invoked when the
+// entry's level clears the logger's configured level, so callers can defer
+// expensive formatting, JSON marshaling, or reflection-heavy diagnostics
+// until it's known the message will actually be emitted.
+type LogFunction func() string
+
+// FieldsFunction builds a set of fields lazily, evaluated under the same
+// condition as LogFunction.
+type FieldsFunction func() Fields
+
+// WithFieldsFn adds fields to the logger, computed lazily from fn only when
+// a subsequent log call clears the logger's level. Unlike AddFields, fn
+// itself isn't invoked here: it's carried on the returned logger and only
+// evaluated inside log(), once the level that call actually logs at is
+// known.
+func (l *Logger) WithFieldsFn(fn FieldsFunction) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	newLogger := l.clone()
+	newLogger.lazyFields = append(append([]FieldsFunction(nil), l.lazyFields...), fn)
+	return newLogger
+}
+
+// DebugFn logs the message returned by fn if debug logging is enabled.
+func (l *Logger) DebugFn(fn LogFunction) {
+	if LevelDebug < l.level {
+		return
+	}
+	l.Debug(fn())
+}
+
+// InfoFn logs the message returned by fn if info logging is enabled.
+func (l *Logger) InfoFn(fn LogFunction) {
+	if LevelInfo < l.level {
+		return
+	}
+	l.Info(fn())
+}
+
+// WarnFn logs the message returned by fn if warn logging is enabled.
+func (l *Logger) WarnFn(fn LogFunction) {
+	if LevelWarn < l.level {
+		return
+	}
+	l.Warn(fn())
+}
+
+// ErrorFn logs the message returned by fn if error logging is enabled.
+func (l *Logger) ErrorFn(fn LogFunction) {
+	if LevelError < l.level {
+		return
+	}
+	l.Error(fn())
+}
+
+// FatalFn logs the message returned by fn, then exits. Like Fatal, it exits
+// even when fatal logging is disabled; fn is then not called.
+func (l *Logger) FatalFn(fn LogFunction) {
+	if LevelFatal < l.level {
+		l.Flush()
+		os.Exit(1)
+	}
+	l.Fatal(fn())
+}
+
+// PanicFn logs the message returned by fn, then panics.
+func (l *Logger) PanicFn(fn LogFunction) {
+	if LevelPanic < l.level {
+		return
+	}
+	l.Panic(fn())
+}
+
+func DebugFn(fn LogFunction) { defaultLogger.DebugFn(fn) }
+func InfoFn(fn LogFunction)  { defaultLogger.InfoFn(fn) }
+func WarnFn(fn LogFunction)  { defaultLogger.WarnFn(fn) }
+func ErrorFn(fn LogFunction) { defaultLogger.ErrorFn(fn) }
+func FatalFn(fn LogFunction) { defaultLogger.FatalFn(fn) }
+func PanicFn(fn LogFunction) { defaultLogger.PanicFn(fn) }
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"io"
+	"testing"
+)
+
+// TestWithFieldsFnLazy confirms fn is only invoked once a subsequent log
+// call actually clears the logger's level, not when WithFieldsFn is called.
+func TestWithFieldsFnLazy(t *testing.T) {
+	l := New(io.Discard)
+	l.SetLevel(LevelError)
+
+	called := false
+	l.WithFieldsFn(func() Fields {
+		called = true
+		return Fields{"expensive": true}
+	}).Debug("filtered out")
+
+	if called {
+		t.Fatal("WithFieldsFn's fn ran even though Debug is below the configured level")
+	}
+
+	l.WithFieldsFn(func() Fields {
+		called = true
+		return Fields{"expensive": true}
+	}).Error("not filtered")
+
+	if !called {
+		t.Fatal("WithFieldsFn's fn did not run for a level that clears the logger's level")
+	}
+}
+
+// TestDebugFnLazy confirms DebugFn's fn is skipped entirely when debug
+// logging is disabled.
+func TestDebugFnLazy(t *testing.T) {
+	l := New(io.Discard)
+	l.SetLevel(LevelInfo)
+
+	called := false
+	l.DebugFn(func() string {
+		called = true
+		return "expensive"
+	})
+
+	if called {
+		t.Fatal("DebugFn's fn ran even though debug logging is disabled")
+	}
+}
@@ -1,12 +1,12 @@
 package logger
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -40,22 +40,43 @@ func (l LogLevel) String() string {
 type Fields map[string]interface{}
 
 type Logger struct {
-	level      LogLevel
-	logger     *log.Logger
-	mu         sync.Mutex
-	fields     Fields
-	output     io.Writer
-	timeFormat string
+	level            LogLevel
+	logger           *log.Logger
+	mu               sync.Mutex
+	fields           Fields
+	output           io.Writer
+	timeFormat       string
+	formatter        Formatter
+	hooks            LevelHooks
+	hookErrorHandler HookErrorHandler
+	asyncCh          chan asyncMsg
+	asyncMode        DropMode
+	asyncDone        chan struct{}
+	asyncWG          *sync.WaitGroup
+	sampler          Sampler
+	droppedCount     int64
+	sampleStop       chan struct{}
+	errorKey         string
+	lazyFields       []FieldsFunction
+
+	// reportCaller records the file, line, and function of the call site on
+	// every entry when non-zero. It's read via reportCallerEnabled() without
+	// holding l.mu, since log() is on the hot path, so it's stored as an
+	// atomic flag rather than a plain bool (see SetReportCaller).
+	reportCaller int32
 }
 
 // New creates a new logger instance with the specified output destination
 func New(output io.Writer) *Logger {
 	return &Logger{
-		level:      LevelInfo,
-		logger:     log.New(output, "", log.LstdFlags),
-		output:     output,
-		timeFormat: "2006-01-02 15:04:05",
-		fields:     make(Fields),
+		level:            LevelInfo,
+		logger:           log.New(output, "", log.LstdFlags),
+		output:           output,
+		timeFormat:       "2006-01-02 15:04:05",
+		fields:           make(Fields),
+		formatter:        &JSONFormatter{},
+		hooks:            make(LevelHooks),
+		hookErrorHandler: defaultHookErrorHandler,
 	}
 }
 
@@ -73,6 +94,9 @@ func (l *Logger) SetOutput(output io.Writer) *Logger {
 	defer l.mu.Unlock()
 	l.output = output
 	l.logger.SetOutput(output)
+	if tf, ok := l.formatter.(*TextFormatter); ok {
+		tf.setIsTerminal(isTerminalWriter(output))
+	}
 	return l
 }
 
@@ -84,6 +108,17 @@ func (l *Logger) SetTimeFormat(format string) *Logger {
 	return l
 }
 
+// SetFormatter sets the formatter used to serialize log entries.
+func (l *Logger) SetFormatter(formatter Formatter) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if tf, ok := formatter.(*TextFormatter); ok {
+		tf.setIsTerminal(isTerminalWriter(l.output))
+	}
+	l.formatter = formatter
+	return l
+}
+
 // AddField adds a field to the logger.
 func (l *Logger) AddField(key string, value interface{}) *Logger {
 	l.mu.Lock()
@@ -110,11 +145,22 @@ func (l *Logger) clone() *Logger {
 		fields[k] = v
 	}
 	return &Logger{
-		level:      l.level,
-		logger:     l.logger,
-		output:     l.output,
-		timeFormat: l.timeFormat,
-		fields:     fields,
+		level:            l.level,
+		logger:           l.logger,
+		output:           l.output,
+		timeFormat:       l.timeFormat,
+		fields:           fields,
+		formatter:        l.formatter,
+		hooks:            l.hooks.clone(),
+		hookErrorHandler: l.hookErrorHandler,
+		asyncCh:          l.asyncCh,
+		asyncMode:        l.asyncMode,
+		asyncDone:        l.asyncDone,
+		asyncWG:          l.asyncWG,
+		sampler:          l.sampler,
+		errorKey:         l.errorKey,
+		lazyFields:       l.lazyFields,
+		reportCaller:     atomic.LoadInt32(&l.reportCaller),
 	}
 }
 
@@ -123,29 +169,56 @@ func (l *Logger) log(level LogLevel, msg string) {
 		return
 	}
 
-	// Format the log entry
-	entry := Fields{
-		"level":   level.String(),
-		"message": msg,
-		"time":    time.Now().Format(l.timeFormat),
+	fields := l.fields
+	if len(l.lazyFields) > 0 {
+		merged := make(Fields, len(l.fields))
+		for k, v := range l.fields {
+			merged[k] = v
+		}
+		for _, fn := range l.lazyFields {
+			for k, v := range fn() {
+				merged[k] = v
+			}
+		}
+		fields = merged
+	}
+	if l.reportCallerEnabled() {
+		fields = l.withCallerFields(fields)
 	}
 
-	// Add context fields
-	for k, v := range l.fields {
-		entry[k] = v
+	entry := &Entry{
+		Level:   level,
+		Message: msg,
+		Time:    time.Now().Format(l.timeFormat),
+		Fields:  fields,
+	}
+
+	if l.sampler != nil && !l.sampler.Allow(entry) {
+		atomic.AddInt64(&l.droppedCount, 1)
+		return
 	}
 
-	// Marshal the log into a json using json.Marshal
-	jsonData, err := json.Marshal(entry)
+	l.emit(entry)
+}
+
+// emit formats entry, fires hooks, and writes the result. Unlike log, it is
+// not subject to sampling, so the sampler's own suppression summaries can
+// always get through.
+func (l *Logger) emit(entry *Entry) {
+	data, err := l.formatter.Format(entry)
 	if err != nil {
-		logMsg := fmt.Sprintf(`{"level": "ERROR","message": "Failed to marshal log entry","error": "%s","time": "%s"}`,
+		logMsg := fmt.Sprintf(`{"level": "ERROR","message": "Failed to format log entry","error": "%s","time": "%s"}`,
 			err.Error(),
 			time.Now().Format(l.timeFormat))
-		fmt.Fprintln(l.output, logMsg)
+		l.write([]byte(logMsg))
 		return
 	}
 
-	fmt.Fprintln(l.output, string(jsonData))
+	l.mu.Lock()
+	l.hooks.fire(entry.Level, entry, l.hookErrorHandler)
+	l.mu.Unlock()
+
+	l.write(data)
 }
 
 // Debug logs a debug message
@@ -168,15 +241,17 @@ func (l *Logger) Error(msg string) {
 	l.log(LevelError, msg)
 }
 
-// Fatal logs a fatal message and exits
+// Fatal logs a fatal message, flushes any buffered output, and exits
 func (l *Logger) Fatal(msg string) {
 	l.log(LevelFatal, msg)
+	l.Flush()
 	os.Exit(1)
 }
 
-// Panic logs a panic message and panics
+// Panic logs a panic message, flushes any buffered output, and panics
 func (l *Logger) Panic(msg string) {
 	l.log(LevelPanic, msg)
+	l.Flush()
 	panic(msg)
 }
 
@@ -187,6 +262,7 @@ var defaultLogger = New(os.Stdout)
 func SetLevel(level LogLevel) *Logger                { return defaultLogger.SetLevel(level) }
 func SetOutput(w io.Writer) *Logger                  { return defaultLogger.SetOutput(w) }
 func SetTimeFormat(format string) *Logger            { return defaultLogger.SetTimeFormat(format) }
+func SetFormatter(formatter Formatter) *Logger       { return defaultLogger.SetFormatter(formatter) }
 func AddField(key string, value interface{}) *Logger { return defaultLogger.AddField(key, value) }
 func AddFields(fields Fields) *Logger                { return defaultLogger.AddFields(fields) }
 func Debug(msg string)                               { defaultLogger.Debug(msg) }
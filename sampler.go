@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides, per entry, whether it should be logged. It runs before
+// formatting so a drop actually saves the cost of serializing the entry.
+type Sampler interface {
+	Allow(entry *Entry) bool
+}
+
+// SetSampler installs sampler on the logger. If reportInterval is positive,
+// a background goroutine periodically emits a summary entry (at Warn level)
+// reporting how many entries the sampler suppressed since the last report,
+// so suppression is never silent. Passing a nil sampler disables sampling
+// and stops any running report goroutine. Only drops made through this
+// logger itself are counted: a logger derived from it afterwards (e.g. via
+// AddField) shares the sampler but its drops are not included in the report.
+func (l *Logger) SetSampler(sampler Sampler, reportInterval time.Duration) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.sampleStop != nil {
+		close(l.sampleStop)
+		l.sampleStop = nil
+	}
+
+	l.sampler = sampler
+	atomic.StoreInt64(&l.droppedCount, 0)
+
+	if sampler == nil || reportInterval <= 0 {
+		return l
+	}
+
+	stop := make(chan struct{})
+	l.sampleStop = stop
+	go l.reportDropped(reportInterval, stop)
+	return l
+}
+
+func (l *Logger) reportDropped(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if dropped := atomic.SwapInt64(&l.droppedCount, 0); dropped > 0 {
+				l.emit(&Entry{
+					Level:   LevelWarn,
+					Message: fmt.Sprintf("sampler suppressed %d log entries", dropped),
+					Time:    time.Now().Format(l.timeFormat),
+					Fields:  l.fields,
+				})
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// RateSampler is a token-bucket rate limiter: it admits up to perSecond
+// entries per second on average, allowing bursts of up to burst entries.
+type RateSampler struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateSampler creates a RateSampler refilling at perSecond tokens per
+// second, holding at most burst tokens.
+func NewRateSampler(perSecond, burst int) *RateSampler {
+	return &RateSampler{
+		rate:   float64(perSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (s *RateSampler) Allow(entry *Entry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.last).Seconds() * s.rate
+	if s.tokens > s.burst {
+		s.tokens = s.burst
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// TieredSampler admits the first N entries for a given (level, message) pair,
+// then every Mth one thereafter. It is the same shape used by zerolog and
+// zap's basic samplers: enough to protect against tight repeat-logging loops
+// without losing data entirely.
+type TieredSampler struct {
+	first      int
+	thereafter int
+
+	mu     sync.Mutex
+	counts map[uint64]int
+}
+
+// NewTieredSampler creates a TieredSampler that admits the first `first`
+// occurrences of each (level, message) pair, then every `thereafter`th one.
+// thereafter is clamped to at least 1 to avoid dividing by zero.
+func NewTieredSampler(first, thereafter int) *TieredSampler {
+	if thereafter < 1 {
+		thereafter = 1
+	}
+	return &TieredSampler{
+		first:      first,
+		thereafter: thereafter,
+		counts:     make(map[uint64]int),
+	}
+}
+
+func (s *TieredSampler) Allow(entry *Entry) bool {
+	key := tieredSampleKey(entry.Level, entry.Message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := s.counts[key]
+	s.counts[key] = n + 1
+
+	if n < s.first {
+		return true
+	}
+	return (n-s.first)%s.thereafter == 0
+}
+
+func tieredSampleKey(level LogLevel, msg string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte{byte(level)})
+	h.Write([]byte(msg))
+	return h.Sum64()
+}
+
+func SetSampler(sampler Sampler, reportInterval time.Duration) *Logger {
+	return defaultLogger.SetSampler(sampler, reportInterval)
+}